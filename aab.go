@@ -2,7 +2,6 @@ package aab
 
 import (
 	"archive/zip"
-	"bytes"
 	"errors"
 	"fmt"
 	"image"
@@ -39,6 +38,7 @@ func (a *Application) isFilled() bool {
 type Aab struct {
 	f         *os.File
 	zipreader *zip.Reader
+	files     map[string]*zip.File
 	manifest  *Manifest
 	resource  *pb.Package
 }
@@ -70,8 +70,13 @@ func OpenZipReader(r io.ReaderAt, size int64) (*Aab, error) {
 	if err != nil {
 		return nil, err
 	}
+	files := make(map[string]*zip.File, len(zipreader.File))
+	for _, file := range zipreader.File {
+		files[file.Name] = file
+	}
 	apk := &Aab{
 		zipreader: zipreader,
+		files:     files,
 		manifest: &Manifest{
 			App: Application{},
 		},
@@ -91,13 +96,8 @@ func (a *Aab) Close() error {
 }
 
 func (a *Aab) parseManifest() error {
-	data, err := a.readZipFile("base/manifest/AndroidManifest.xml")
-	if err != nil {
-		return err
-	}
 	xmlNode := pb.XmlNode{}
-	err = proto.Unmarshal(data, &xmlNode)
-	if err != nil {
+	if err := a.unmarshalEntry("base/manifest/AndroidManifest.xml", &xmlNode); err != nil {
 		return err
 	}
 	element := xmlNode.GetElement()
@@ -145,16 +145,11 @@ outloop:
 }
 
 func (a *Aab) parseResources() error {
-	data, err := a.readZipFile("base/resources.pb")
-	if err != nil {
+	table := pb.ResourceTable{}
+	if err := a.unmarshalEntry("base/resources.pb", &table); err != nil {
 		return err
 	}
-	xmlNode := pb.ResourceTable{}
-	err = proto.Unmarshal(data, &xmlNode)
-	if err != nil {
-		return err
-	}
-	for _, p := range xmlNode.Package {
+	for _, p := range table.Package {
 		if p.PackageName == a.manifest.Package {
 			a.resource = p
 			break
@@ -163,65 +158,73 @@ func (a *Aab) parseResources() error {
 	return nil
 }
 
-func (k *Aab) readZipFile(name string) (data []byte, err error) {
-	buf := bytes.NewBuffer(nil)
-	for _, file := range k.zipreader.File {
-		if file.Name != name {
-			continue
-		}
-		rc, er := file.Open()
-		if er != nil {
-			err = er
-			return
-		}
-		defer rc.Close()
-		_, err = io.Copy(buf, rc)
-		if err != nil {
-			return
-		}
-		return buf.Bytes(), nil
+// OpenEntry opens the zip entry at name for streaming reads. The caller must
+// Close the returned reader. Lookup is O(1) via the index built at open time.
+func (a *Aab) OpenEntry(name string) (io.ReadCloser, error) {
+	file, ok := a.files[name]
+	if !ok {
+		return nil, fmt.Errorf("file %s not found", strconv.Quote(name))
 	}
-	return nil, fmt.Errorf("file %s not found", strconv.Quote(name))
+	return file.Open()
 }
 
-func (a *Aab) findResource(t, name string, config *pb.Configuration) string {
-	if a.resource == nil {
-		return ""
+// unmarshalEntry streams the entry at name and unmarshals it into msg.
+// Protobuf decoding needs the whole message in memory, but this still avoids
+// the linear directory scan a lookup by name used to require.
+func (a *Aab) unmarshalEntry(name string, msg proto.Message) error {
+	rc, err := a.OpenEntry(name)
+	if err != nil {
+		return err
 	}
-	var value *pb.Value
-	for _, tt := range a.resource.Type {
-		if tt.Name == t {
-			if tt.Entry != nil {
-				for _, e := range tt.Entry {
-					if e.Name == name {
-						for _, c := range e.ConfigValue {
-							if matchConfig(config, c.Config) {
-								value = c.Value
-								break
-							}
-						}
-					}
-				}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(data, msg)
+}
 
+// resolveResource looks up the t/name entry (e.g. "mipmap/ic_launcher") in a
+// module's resource table and returns the best-matching value's path or
+// string content for config, shared by the base Aab and its Modules.
+func resolveResource(resource *pb.Package, t, name string, config *pb.Configuration) (string, error) {
+	if resource == nil {
+		return "", fmt.Errorf("no resource table available for %s/%s", t, name)
+	}
+	var candidates []*pb.Configuration
+	configByValue := map[*pb.Configuration]*pb.Value{}
+	for _, tt := range resource.Type {
+		if tt.Name != t {
+			continue
+		}
+		for _, e := range tt.Entry {
+			if e.Name != name {
+				continue
+			}
+			for _, c := range e.ConfigValue {
+				candidates = append(candidates, c.Config)
+				configByValue[c.Config] = c.Value
 			}
 		}
 	}
-	if value != nil {
-		if item := value.GetItem(); item != nil {
-			switch t {
-			case "mipmap", "drawable":
-				if file := item.GetFile(); file != nil {
-					return file.Path
-				}
-			case "string":
-				if str := item.GetStr(); str != nil {
-					return str.Value
-				}
+	best := SelectBestConfig(candidates, config)
+	if best == nil {
+		return "", fmt.Errorf("no compatible %s/%s resource for the given configuration", t, name)
+	}
+	value := configByValue[best]
+	if item := value.GetItem(); item != nil {
+		switch t {
+		case "mipmap", "drawable":
+			if file := item.GetFile(); file != nil {
+				return file.Path, nil
+			}
+		case "string":
+			if str := item.GetStr(); str != nil {
+				return str.Value, nil
 			}
-
 		}
 	}
-	return ""
+	return "", fmt.Errorf("%s/%s resource has no usable value", t, name)
 }
 
 func (a *Aab) PackageName() string {
@@ -233,42 +236,49 @@ func (a *Aab) Manifest() *Manifest {
 }
 
 func (a *Aab) Icon(config *pb.Configuration) (image.Image, error) {
-	if len(a.manifest.App.Icon) == 0 {
+	return a.icon("base", a.resource, &a.manifest.App, config)
+}
+
+func (a *Aab) Label(config *pb.Configuration) string {
+	return label(a.resource, &a.manifest.App, config)
+}
+
+// icon decodes the icon resource referenced by app, reading it from the
+// module directory named by prefix.
+func (a *Aab) icon(prefix string, resource *pb.Package, app *Application, config *pb.Configuration) (image.Image, error) {
+	if len(app.Icon) == 0 {
 		return nil, errors.New("not found icon resource")
 	}
-	parts := strings.Split(a.manifest.App.Icon, "/")
+	parts := strings.Split(app.Icon, "/")
 	if len(parts) != 2 {
 		return nil, errors.New("invalid icon resource")
 	}
-	iconPath := a.findResource(parts[0], parts[1], config)
-	if len(iconPath) > 0 {
-		imageData, err := a.readZipFile("base/" + iconPath)
-		if err != nil {
-			return nil, err
-		}
-		m, _, err := image.Decode(bytes.NewReader(imageData))
-		return m, err
+	iconPath, err := resolveResource(resource, parts[0], parts[1], config)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, errors.New("not found icon resource")
-}
-
-func (a *Aab) Label(config *pb.Configuration) string {
-	if len(a.manifest.App.Label) > 0 {
-		parts := strings.Split(a.manifest.App.Label, "/")
-		if len(parts) != 2 {
-			return ""
-		}
-		return a.findResource(parts[0], parts[1], config)
+	rc, err := a.OpenEntry(prefix + "/" + iconPath)
+	if err != nil {
+		return nil, err
 	}
-
-	return ""
+	defer rc.Close()
+	m, _, err := image.Decode(rc)
+	return m, err
 }
 
-func matchConfig(a, b *pb.Configuration) bool {
-	if a != nil && a.Density > 0 && a.Density != b.Density {
-		return false
+// label resolves the label resource referenced by app, returning "" if it
+// cannot be resolved.
+func label(resource *pb.Package, app *Application, config *pb.Configuration) string {
+	if len(app.Label) == 0 {
+		return ""
+	}
+	parts := strings.Split(app.Label, "/")
+	if len(parts) != 2 {
+		return ""
+	}
+	value, err := resolveResource(resource, parts[0], parts[1], config)
+	if err != nil {
+		return ""
 	}
-	//TODO: support other configurations
-	return true
+	return value
 }
@@ -1,6 +1,7 @@
 package aab
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,3 +29,29 @@ func TestParseAab(t *testing.T) {
 		t.Fatal("no icon")
 	}
 }
+
+func TestOpenEntryStreamsContent(t *testing.T) {
+	aab := newZipAab(t, map[string][]byte{
+		"base/resources.pb": []byte("fake resource table bytes"),
+	})
+
+	rc, err := aab.OpenEntry("base/resources.pb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "fake resource table bytes", string(data))
+}
+
+func TestOpenEntryNotFound(t *testing.T) {
+	aab := newZipAab(t, map[string][]byte{
+		"base/resources.pb": []byte("fake resource table bytes"),
+	})
+
+	_, err := aab.OpenEntry("base/does-not-exist.pb")
+	assert.Error(t, err)
+}
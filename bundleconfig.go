@@ -0,0 +1,26 @@
+package aab
+
+import (
+	"io"
+
+	"github.com/xmxu/aab-parser/pb"
+)
+
+const bundleConfigPath = "BUNDLE-METADATA/com.android.tools.build.bundletool/BundleConfig.pb"
+
+// BundleConfig returns the bundletool version, compression settings, and
+// configured split dimensions recorded in the AAB's BundleConfig.pb, the
+// same metadata `bundletool` itself reads to decide how to build split
+// APKs.
+func (a *Aab) BundleConfig() (*pb.BundleConfig, error) {
+	rc, err := a.OpenEntry(bundleConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	return pb.UnmarshalBundleConfig(data)
+}
@@ -0,0 +1,30 @@
+package aab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestBundleConfigReadsEmbeddedMetadata(t *testing.T) {
+	bundletool := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), []byte("1.15.0"))
+	data := protowire.AppendBytes(protowire.AppendTag(nil, 1, protowire.BytesType), bundletool)
+
+	aab := newZipAab(t, map[string][]byte{
+		bundleConfigPath: data,
+	})
+
+	cfg, err := aab.BundleConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "1.15.0", cfg.Bundletool.Version)
+}
+
+func TestBundleConfigMissing(t *testing.T) {
+	aab := newZipAab(t, map[string][]byte{})
+
+	_, err := aab.BundleConfig()
+	assert.Error(t, err)
+}
@@ -0,0 +1,218 @@
+package aab
+
+import (
+	"strings"
+
+	"github.com/xmxu/aab-parser/pb"
+)
+
+// standard Android bucketed densities, used for the scaled-distance rule.
+var standardDensities = []uint32{120, 160, 213, 240, 320, 360, 400, 420, 480, 560, 640}
+
+// Android reserves two density qualifiers outside the normal dpi range:
+// DENSITY_ANY (drawable-anydpi, e.g. a vector or adaptive-icon XML) is
+// preferred over any fixed-density bitmap whenever the target has a density, and
+// DENSITY_NONE (drawable-nodpi) is density-independent and never scaled.
+// Both must be special-cased before bucketing; otherwise their raw values
+// (0xFFFE/0xFFFF) get treated as enormous ordinary densities.
+const (
+	densityAny  uint32 = 0xFFFE
+	densityNone uint32 = 0xFFFF
+)
+
+// SelectBestConfig picks the candidate configuration that best matches target,
+// following Android's resource-selection precedence: locale, screen layout,
+// orientation, density, UI mode, layout direction, ABI, then SDK version.
+// It returns nil if none of the candidates are compatible with target.
+func SelectBestConfig(candidates []*pb.Configuration, target *pb.Configuration) *pb.Configuration {
+	var best *pb.Configuration
+	var bestScore []int
+	for _, c := range candidates {
+		score, ok := scoreConfig(c, target)
+		if !ok {
+			continue
+		}
+		if best == nil || less(bestScore, score) {
+			best = c
+			bestScore = score
+		}
+	}
+	return best
+}
+
+// scoreConfig returns a precedence-ordered score for c against target, higher
+// is better at each position. ok is false if c is incompatible with target
+// (e.g. it requires an SDK version target can't satisfy).
+func scoreConfig(c, target *pb.Configuration) (score []int, ok bool) {
+	localeScore, localeOK := matchLocale(c, target)
+	if !localeOK {
+		return nil, false
+	}
+	if target != nil && target.SdkVersion > 0 && c.SdkVersion > target.SdkVersion {
+		return nil, false
+	}
+	score = []int{
+		localeScore,
+		matchScreenLayoutSize(c, target),
+		matchOrientation(c, target),
+		matchDensity(c, target),
+		matchUiModeNight(c, target),
+		matchLayoutDirection(c, target),
+		matchAbi(c, target),
+		int(c.SdkVersion),
+	}
+	return score, true
+}
+
+// less reports whether a is a worse (lower-precedence) score than b,
+// comparing position by position, earlier positions dominating later ones.
+func less(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func matchLocale(c, target *pb.Configuration) (score int, ok bool) {
+	if target == nil || target.Locale == "" {
+		if c.Locale == "" {
+			return 2, true
+		}
+		return 0, true
+	}
+	if c.Locale == "" {
+		return 0, true
+	}
+	tLang, tRegion := splitLocale(target.Locale)
+	cLang, cRegion := splitLocale(c.Locale)
+	if cLang != tLang {
+		return 0, false
+	}
+	if cRegion == tRegion {
+		return 3, true
+	}
+	if cRegion == "" {
+		return 2, true
+	}
+	return 0, false
+}
+
+func splitLocale(locale string) (lang, region string) {
+	parts := strings.Split(locale, "-")
+	lang = strings.ToLower(parts[0])
+	if len(parts) > 1 {
+		region = strings.ToUpper(parts[len(parts)-1])
+	}
+	return
+}
+
+func matchScreenLayoutSize(c, target *pb.Configuration) int {
+	if target == nil || target.ScreenLayoutSize == 0 {
+		return 0
+	}
+	if c.ScreenLayoutSize == 0 {
+		return 0
+	}
+	if c.ScreenLayoutSize > target.ScreenLayoutSize {
+		return -1
+	}
+	if c.ScreenLayoutSize == target.ScreenLayoutSize {
+		return 2
+	}
+	return 1
+}
+
+func matchOrientation(c, target *pb.Configuration) int {
+	if target == nil || target.Orientation == 0 {
+		return 0
+	}
+	if c.Orientation == 0 {
+		return 0
+	}
+	if c.Orientation == target.Orientation {
+		return 1
+	}
+	return -1
+}
+
+// matchDensity implements Android's scaled-distance rule: the closest
+// density that is >= target wins; if none is >=, the closest lower density
+// wins. An entry with no density (density-independent, e.g. nodpi) always
+// matches with a neutral score. anydpi (e.g. a vector or adaptive-icon
+// drawable) outscores every fixed-density bitmap when target has a density;
+// with a nil target or no target density, every candidate scores 0 here.
+func matchDensity(c, target *pb.Configuration) int {
+	if target == nil || target.Density == 0 || c.Density == 0 {
+		return 0
+	}
+	if c.Density == densityNone {
+		return 0
+	}
+	if c.Density == densityAny {
+		return 1 << 30
+	}
+	if c.Density == target.Density {
+		return 1 << 20
+	}
+	cBucket := bucketDensity(c.Density)
+	tBucket := bucketDensity(target.Density)
+	if cBucket >= tBucket {
+		return 1<<19 - int(cBucket-tBucket)
+	}
+	return int(cBucket) - (1 << 19)
+}
+
+// bucketDensity maps an arbitrary density to the nearest standard bucket at
+// or above it, matching how Android classifies non-standard densities. The
+// densityAny/densityNone sentinels must be handled by the caller before
+// reaching here — they are not ordinary densities and don't belong in a
+// bucket.
+func bucketDensity(density uint32) uint32 {
+	for _, d := range standardDensities {
+		if density <= d {
+			return d
+		}
+	}
+	return density
+}
+
+func matchUiModeNight(c, target *pb.Configuration) int {
+	if target == nil || target.UiModeNight == 0 {
+		return 0
+	}
+	if c.UiModeNight == 0 {
+		return 0
+	}
+	if c.UiModeNight == target.UiModeNight {
+		return 1
+	}
+	return -1
+}
+
+func matchLayoutDirection(c, target *pb.Configuration) int {
+	if target == nil || target.LayoutDirection == 0 {
+		return 0
+	}
+	if c.LayoutDirection == 0 {
+		return 0
+	}
+	if c.LayoutDirection == target.LayoutDirection {
+		return 1
+	}
+	return -1
+}
+
+func matchAbi(c, target *pb.Configuration) int {
+	if target == nil || target.Abi == "" {
+		return 0
+	}
+	if c.Abi == "" {
+		return 0
+	}
+	if c.Abi == target.Abi {
+		return 1
+	}
+	return -1
+}
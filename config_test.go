@@ -0,0 +1,90 @@
+package aab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmxu/aab-parser/pb"
+)
+
+func TestSelectBestConfigDensity(t *testing.T) {
+	xhdpi := &pb.Configuration{Density: 320}
+	xxhdpi := &pb.Configuration{Density: 480}
+	xxxhdpi := &pb.Configuration{Density: 640}
+	candidates := []*pb.Configuration{xhdpi, xxhdpi, xxxhdpi}
+
+	// Exact match wins outright.
+	assert.Same(t, xxhdpi, SelectBestConfig(candidates, &pb.Configuration{Density: 480}))
+
+	// No exact match: the closest higher density wins over the closest lower one.
+	assert.Same(t, xxhdpi, SelectBestConfig(candidates, &pb.Configuration{Density: 400}))
+
+	// Target density higher than anything available: falls back to the closest lower density.
+	assert.Same(t, xxxhdpi, SelectBestConfig(candidates, &pb.Configuration{Density: 1000}))
+}
+
+func TestSelectBestConfigDensitySentinels(t *testing.T) {
+	xxhdpi := &pb.Configuration{Density: 480}
+	anydpi := &pb.Configuration{Density: 0xFFFE} // mipmap-anydpi-v26, e.g. an adaptive-icon XML
+	nodpi := &pb.Configuration{Density: 0xFFFF}  // drawable-nodpi, density-independent
+
+	// anydpi (e.g. an adaptive-icon) outscores an exact bitmap match when present.
+	assert.Same(t, anydpi, SelectBestConfig([]*pb.Configuration{xxhdpi, anydpi}, &pb.Configuration{Density: 480}))
+
+	// nodpi is density-independent: it never beats a real density match.
+	assert.Same(t, xxhdpi, SelectBestConfig([]*pb.Configuration{xxhdpi, nodpi}, &pb.Configuration{Density: 480}))
+}
+
+func TestSelectBestConfigLocale(t *testing.T) {
+	def := &pb.Configuration{}
+	en := &pb.Configuration{Locale: "en"}
+	enUS := &pb.Configuration{Locale: "en-US"}
+	fr := &pb.Configuration{Locale: "fr"}
+	candidates := []*pb.Configuration{def, en, enUS, fr}
+
+	// Region-specific match beats the language-only parent fallback.
+	assert.Same(t, enUS, SelectBestConfig(candidates, &pb.Configuration{Locale: "en-US"}))
+
+	// No region-specific entry: the language-only parent still matches.
+	assert.Same(t, en, SelectBestConfig(candidates, &pb.Configuration{Locale: "en-GB"}))
+
+	// No matching language at all among candidates: fr can't satisfy a de target,
+	// and no locale-less default candidate is offered to fall back on.
+	assert.Nil(t, SelectBestConfig([]*pb.Configuration{fr}, &pb.Configuration{Locale: "de"}))
+}
+
+func TestSelectBestConfigSdkVersionIncompatible(t *testing.T) {
+	tooNew := &pb.Configuration{SdkVersion: 33}
+	ok := &pb.Configuration{SdkVersion: 21}
+	candidates := []*pb.Configuration{tooNew, ok}
+
+	assert.Same(t, ok, SelectBestConfig(candidates, &pb.Configuration{SdkVersion: 24}))
+}
+
+func TestSelectBestConfigNoCandidates(t *testing.T) {
+	assert.Nil(t, SelectBestConfig(nil, &pb.Configuration{Density: 480}))
+}
+
+func TestBucketDensity(t *testing.T) {
+	cases := []struct {
+		density uint32
+		want    uint32
+	}{
+		{density: 160, want: 160},
+		{density: 180, want: 213},
+		{density: 700, want: 700}, // above the highest standard bucket: returned unchanged
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, bucketDensity(c.density))
+	}
+}
+
+func TestSplitLocale(t *testing.T) {
+	lang, region := splitLocale("en-US")
+	assert.Equal(t, "en", lang)
+	assert.Equal(t, "US", region)
+
+	lang, region = splitLocale("fr")
+	assert.Equal(t, "fr", lang)
+	assert.Equal(t, "", region)
+}
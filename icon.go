@@ -0,0 +1,235 @@
+package aab
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	stddraw "image/draw"
+	"strings"
+
+	"golang.org/x/image/draw"
+
+	"github.com/xmxu/aab-parser/pb"
+)
+
+// baseIconDp is the nominal size (in dp) Android launcher icons are
+// designed at; it lets us convert a resource's density qualifier into an
+// approximate pixel size for density selection.
+const baseIconDp = 48
+
+// adaptiveIconCanvasDp and adaptiveIconViewportDp are the standard
+// adaptive-icon canvas size and the masked viewport within it that
+// launchers actually show (https://developer.android.com/develop/ui/views/launch/icon_design_adaptive).
+const (
+	adaptiveIconCanvasDp   = 108
+	adaptiveIconViewportDp = 72
+)
+
+// IconAt renders the app's launcher icon at exactly px by px pixels,
+// automatically picking the smallest available density that is at least as
+// large as px and resampling down to size. If the icon resource is an
+// adaptive-icon XML, its background and foreground layers are rendered and
+// composited into the standard 108dp canvas cropped to the 72dp masked
+// viewport; otherwise the raw bitmap is resampled directly.
+func (a *Aab) IconAt(px int) (image.Image, error) {
+	if len(a.manifest.App.Icon) == 0 {
+		return nil, errors.New("not found icon resource")
+	}
+	parts := strings.Split(a.manifest.App.Icon, "/")
+	if len(parts) != 2 {
+		return nil, errors.New("invalid icon resource")
+	}
+	path, isAdaptive, err := a.bestIconPath(parts[0], parts[1], px)
+	if err != nil {
+		return nil, err
+	}
+	if isAdaptive {
+		return a.renderAdaptiveIcon(path, px)
+	}
+	return a.decodeAndResample(path, px)
+}
+
+// bestIconPath picks the ConfigValue for t/name whose density is the
+// smallest one at least as large as px, falling back to the largest
+// available density if none is big enough. It reports whether the chosen
+// entry is a compiled adaptive-icon XML rather than a raster image.
+func (a *Aab) bestIconPath(t, name string, px int) (path string, isAdaptive bool, err error) {
+	if a.resource == nil {
+		return "", false, fmt.Errorf("no resource table for package %s", a.manifest.Package)
+	}
+	type candidate struct {
+		density uint32
+		path    string
+	}
+	var candidates []candidate
+	for _, tt := range a.resource.Type {
+		if tt.Name != t {
+			continue
+		}
+		for _, e := range tt.Entry {
+			if e.Name != name {
+				continue
+			}
+			for _, c := range e.ConfigValue {
+				item := c.Value.GetItem()
+				if item == nil {
+					continue
+				}
+				file := item.GetFile()
+				if file == nil {
+					continue
+				}
+				candidates = append(candidates, candidate{density: c.Config.GetDensity(), path: file.Path})
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false, fmt.Errorf("no %s/%s resource found", t, name)
+	}
+
+	// anydpi (e.g. a vector or adaptive-icon XML) is resolution-independent
+	// and always preferred over a fixed-density bitmap when present.
+	for i := range candidates {
+		if candidates[i].density == densityAny {
+			return candidates[i].path, true, nil
+		}
+	}
+
+	var best *candidate
+	for i := range candidates {
+		c := &candidates[i]
+		if densityToPx(c.density) < px {
+			continue
+		}
+		if best == nil || densityToPx(c.density) < densityToPx(best.density) {
+			best = c
+		}
+	}
+	if best == nil {
+		for i := range candidates {
+			c := &candidates[i]
+			if best == nil || densityToPx(c.density) > densityToPx(best.density) {
+				best = c
+			}
+		}
+	}
+	return best.path, strings.HasSuffix(best.path, ".xml"), nil
+}
+
+// densityToPx converts a density qualifier into an approximate pixel size
+// for an icon nominally designed at baseIconDp. density 0 (unspecified) and
+// densityNone (drawable-nodpi) are density-independent and treated as
+// already right-sized; densityAny (drawable-anydpi) is handled by the
+// caller before this is reached, since it isn't a fixed pixel size at all.
+func densityToPx(density uint32) int {
+	if density == 0 || density == densityNone {
+		return baseIconDp
+	}
+	return int(density) * baseIconDp / 160
+}
+
+func (a *Aab) decodeAndResample(path string, px int) (image.Image, error) {
+	rc, err := a.OpenEntry("base/" + path)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	src, _, err := image.Decode(rc)
+	if err != nil {
+		return nil, err
+	}
+	return resample(src, px), nil
+}
+
+func resample(src image.Image, px int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, px, px))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, src.Bounds(), draw.Over, nil)
+	return dst
+}
+
+// renderAdaptiveIcon decodes the compiled XML adaptive-icon at path,
+// renders its background and foreground layers, and composites them into
+// the standard 108dp canvas cropped to the 72dp masked viewport.
+func (a *Aab) renderAdaptiveIcon(path string, px int) (image.Image, error) {
+	xmlNode := pb.XmlNode{}
+	if err := a.unmarshalEntry("base/"+path, &xmlNode); err != nil {
+		return nil, err
+	}
+	root := xmlNode.GetElement()
+	if root == nil || root.Name != "adaptive-icon" {
+		return nil, fmt.Errorf("%s is not an adaptive-icon", path)
+	}
+
+	var backgroundRef, foregroundRef string
+	for _, child := range root.Child {
+		layer := child.GetElement()
+		if layer == nil {
+			continue
+		}
+		ref := layerDrawableRef(layer)
+		switch layer.Name {
+		case "background":
+			backgroundRef = ref
+		case "foreground":
+			foregroundRef = ref
+		}
+	}
+
+	canvasPx := px * adaptiveIconCanvasDp / adaptiveIconViewportDp
+	canvas := image.NewRGBA(image.Rect(0, 0, canvasPx, canvasPx))
+	if backgroundRef != "" {
+		layer, err := a.renderIconLayer(backgroundRef, canvasPx)
+		if err != nil {
+			return nil, err
+		}
+		stddraw.Draw(canvas, canvas.Bounds(), layer, image.Point{}, stddraw.Over)
+	}
+	if foregroundRef != "" {
+		layer, err := a.renderIconLayer(foregroundRef, canvasPx)
+		if err != nil {
+			return nil, err
+		}
+		stddraw.Draw(canvas, canvas.Bounds(), layer, image.Point{}, stddraw.Over)
+	}
+
+	offset := (canvasPx - px) / 2
+	viewport := canvas.SubImage(image.Rect(offset, offset, offset+px, offset+px))
+	out := image.NewRGBA(image.Rect(0, 0, px, px))
+	stddraw.Draw(out, out.Bounds(), viewport, image.Point{X: offset, Y: offset}, stddraw.Src)
+	return out, nil
+}
+
+func layerDrawableRef(layer *pb.XmlElement) string {
+	for _, attr := range layer.Attribute {
+		if attr.GetName() != "drawable" {
+			continue
+		}
+		if item := attr.GetCompiledItem(); item != nil {
+			if ref := item.GetRef(); ref != nil {
+				return ref.GetName()
+			}
+		}
+	}
+	return ""
+}
+
+func (a *Aab) renderIconLayer(ref string, px int) (image.Image, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid drawable reference %q", ref)
+	}
+	path, err := resolveResource(a.resource, parts[0], parts[1], nil)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := a.OpenEntry("base/" + path)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	src, _, err := image.Decode(rc)
+	if err != nil {
+		return nil, err
+	}
+	return resample(src, px), nil
+}
@@ -0,0 +1,109 @@
+package aab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmxu/aab-parser/pb"
+)
+
+func fileEntry(density uint32, path string) *pb.ConfigValue {
+	return &pb.ConfigValue{
+		Config: &pb.Configuration{Density: density},
+		Value: &pb.Value{
+			Value: &pb.Value_Item{
+				Item: &pb.Item{
+					Value: &pb.Item_File{File: &pb.FileReference{Path: path}},
+				},
+			},
+		},
+	}
+}
+
+func newIconAab(t *testing.T, values ...*pb.ConfigValue) *Aab {
+	t.Helper()
+	aab := newZipAab(t, nil)
+	aab.manifest.Package = "com.example.app"
+	aab.resource = &pb.Package{
+		Type: []*pb.Type{
+			{
+				Name: "mipmap",
+				Entry: []*pb.Entry{
+					{Name: "ic_launcher", ConfigValue: values},
+				},
+			},
+		},
+	}
+	return aab
+}
+
+func TestBestIconPathPicksSmallestDensityAboveTarget(t *testing.T) {
+	aab := newIconAab(t,
+		fileEntry(160, "res/mipmap-mdpi/ic_launcher.png"),
+		fileEntry(320, "res/mipmap-xhdpi/ic_launcher.png"),
+		fileEntry(480, "res/mipmap-xxhdpi/ic_launcher.png"),
+		fileEntry(640, "res/mipmap-xxxhdpi/ic_launcher.png"),
+	)
+
+	// densityToPx(480) = 144px, the smallest available size >= 150 is 640 -> 192px.
+	path, isAdaptive, err := aab.bestIconPath("mipmap", "ic_launcher", 150)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, isAdaptive)
+	assert.Equal(t, "res/mipmap-xxxhdpi/ic_launcher.png", path)
+}
+
+func TestBestIconPathFallsBackToLargestWhenNoneLargeEnough(t *testing.T) {
+	aab := newIconAab(t,
+		fileEntry(160, "res/mipmap-mdpi/ic_launcher.png"),
+		fileEntry(320, "res/mipmap-xhdpi/ic_launcher.png"),
+	)
+
+	path, _, err := aab.bestIconPath("mipmap", "ic_launcher", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "res/mipmap-xhdpi/ic_launcher.png", path)
+}
+
+func TestBestIconPathDetectsAdaptiveIcon(t *testing.T) {
+	// aapt2 encodes mipmap-anydpi-v26 with the real DENSITY_ANY sentinel
+	// (0xFFFE), not density 0.
+	aab := newIconAab(t,
+		fileEntry(0xFFFE, "res/mipmap-anydpi-v26/ic_launcher.xml"),
+	)
+
+	path, isAdaptive, err := aab.bestIconPath("mipmap", "ic_launcher", 48)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, isAdaptive)
+	assert.Equal(t, "res/mipmap-anydpi-v26/ic_launcher.xml", path)
+}
+
+func TestBestIconPathPrefersAnydpiOverBitmaps(t *testing.T) {
+	aab := newIconAab(t,
+		fileEntry(480, "res/mipmap-xxhdpi/ic_launcher.png"),
+		fileEntry(640, "res/mipmap-xxxhdpi/ic_launcher.png"),
+		fileEntry(0xFFFE, "res/mipmap-anydpi-v26/ic_launcher.xml"),
+	)
+
+	// Even though a larger bitmap is available, anydpi (a scalable
+	// adaptive-icon) always wins when present, at any requested size.
+	for _, px := range []int{48, 96, 192, 512} {
+		path, isAdaptive, err := aab.bestIconPath("mipmap", "ic_launcher", px)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, isAdaptive)
+		assert.Equal(t, "res/mipmap-anydpi-v26/ic_launcher.xml", path)
+	}
+}
+
+func TestDensityToPx(t *testing.T) {
+	assert.Equal(t, 48, densityToPx(160))    // mdpi baseline: 1x
+	assert.Equal(t, 96, densityToPx(320))    // xhdpi: 2x
+	assert.Equal(t, 48, densityToPx(0))      // unspecified density: density-independent
+	assert.Equal(t, 48, densityToPx(0xFFFF)) // nodpi: density-independent
+}
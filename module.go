@@ -0,0 +1,182 @@
+package aab
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+
+	"github.com/xmxu/aab-parser/pb"
+)
+
+// errNotAModule marks a top-level zip directory (e.g. BUNDLE-METADATA/,
+// META-INF/) that isn't a module, as opposed to a module whose manifest or
+// resource table failed to parse.
+var errNotAModule = errors.New("not a module directory")
+
+// DeliveryType describes when a dynamic feature module is installed,
+// mirroring the <dist:module> dist:on-demand / install-time / fast-follow
+// delivery options bundletool supports.
+type DeliveryType int
+
+const (
+	DeliveryUnknown DeliveryType = iota
+	DeliveryInstallTime
+	DeliveryOnDemand
+	DeliveryFastFollow
+)
+
+// Module is a single module directory inside an AAB: the base module, a
+// dynamic feature module, or an asset pack.
+type Module struct {
+	Name     string
+	Delivery DeliveryType
+
+	aab      *Aab
+	manifest *Manifest
+	resource *pb.Package
+}
+
+// Modules discovers every module directory in the zip (base, dynamic
+// features, and asset packs), parsing each module's manifest and resource
+// table.
+func (a *Aab) Modules() ([]*Module, error) {
+	seen := map[string]bool{}
+	var dirs []string
+	for name := range a.files {
+		dir, _, ok := strings.Cut(name, "/")
+		if !ok || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	var modules []*Module
+	for _, dir := range dirs {
+		m, err := a.parseModule(dir)
+		if errors.Is(err, errNotAModule) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing module %s: %w", dir, err)
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+func (a *Aab) parseModule(name string) (*Module, error) {
+	manifestPath := name + "/manifest/AndroidManifest.xml"
+	if _, ok := a.files[manifestPath]; !ok {
+		return nil, errNotAModule
+	}
+	xmlNode := pb.XmlNode{}
+	if err := a.unmarshalEntry(manifestPath, &xmlNode); err != nil {
+		return nil, err
+	}
+	element := xmlNode.GetElement()
+
+	m := &Module{Name: name, aab: a, manifest: &Manifest{App: Application{}}}
+	for _, attr := range element.GetAttribute() {
+		if attr.GetName() == "package" {
+			m.manifest.Package = attr.GetValue()
+		}
+	}
+	m.Delivery = parseDelivery(element)
+	for _, child := range element.Child {
+		app := child.GetElement()
+		if app != nil && app.Name == "application" {
+			populateApplication(app, &m.manifest.App)
+			break
+		}
+	}
+
+	resourcePath := name + "/resources.pb"
+	if _, ok := a.files[resourcePath]; ok {
+		table := pb.ResourceTable{}
+		if err := a.unmarshalEntry(resourcePath, &table); err != nil {
+			return nil, err
+		}
+		for _, p := range table.Package {
+			if p.PackageName == m.manifest.Package {
+				m.resource = p
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+// parseDelivery reads the <dist:module> delivery children, defaulting to
+// install-time when none are present, matching bundletool's own default.
+func parseDelivery(manifest *pb.XmlElement) DeliveryType {
+	for _, child := range manifest.Child {
+		module := child.GetElement()
+		if module == nil || module.Name != "module" {
+			continue
+		}
+		for _, dchild := range module.Child {
+			delivery := dchild.GetElement()
+			if delivery == nil {
+				continue
+			}
+			switch delivery.Name {
+			case "on-demand":
+				return DeliveryOnDemand
+			case "fast-follow":
+				return DeliveryFastFollow
+			case "install-time":
+				return DeliveryInstallTime
+			}
+		}
+	}
+	return DeliveryInstallTime
+}
+
+// populateApplication fills app.Icon/app.Label from a module manifest's
+// <application> element, the same lookup parseManifest does for the base
+// module.
+func populateApplication(application *pb.XmlElement, app *Application) {
+	for _, attr := range application.Attribute {
+		item := attr.GetCompiledItem()
+		if item == nil {
+			continue
+		}
+		ref := item.GetRef()
+		if ref == nil {
+			continue
+		}
+		switch attr.GetName() {
+		case "icon":
+			app.Icon = ref.GetName()
+		case "label":
+			app.Label = ref.GetName()
+		}
+		if app.isFilled() {
+			return
+		}
+	}
+}
+
+func (m *Module) PackageName() string {
+	return m.manifest.Package
+}
+
+func (m *Module) Manifest() *Manifest {
+	return m.manifest
+}
+
+// Icon returns this module's own launcher icon, independent of the base
+// module's icon.
+func (m *Module) Icon(config *pb.Configuration) (image.Image, error) {
+	return m.aab.icon(m.Name, m.resource, &m.manifest.App, config)
+}
+
+// Label returns this module's own application label, independent of the
+// base module's label.
+func (m *Module) Label(config *pb.Configuration) string {
+	return label(m.resource, &m.manifest.App, config)
+}
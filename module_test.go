@@ -0,0 +1,87 @@
+package aab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmxu/aab-parser/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+// elementNode wraps an XmlElement the way a compiled XML file stores it, so
+// tests can build manifest trees without a real aapt2/bundletool toolchain.
+func elementNode(e *pb.XmlElement) *pb.XmlNode {
+	return &pb.XmlNode{Node: &pb.XmlNode_Element{Element: e}}
+}
+
+func moduleManifest(t *testing.T, pkg string) []byte {
+	t.Helper()
+	data, err := proto.Marshal(elementNode(&pb.XmlElement{
+		Name:      "manifest",
+		Attribute: []*pb.XmlAttribute{{Name: "package", Value: pkg}},
+	}))
+	if err != nil {
+		t.Fatalf("marshaling manifest: %v", err)
+	}
+	return data
+}
+
+func TestModulesOrderIsDeterministic(t *testing.T) {
+	aab := newZipAab(t, map[string][]byte{
+		"zebra/manifest/AndroidManifest.xml":  moduleManifest(t, "com.example.zebra"),
+		"alpha/manifest/AndroidManifest.xml":  moduleManifest(t, "com.example.alpha"),
+		"middle/manifest/AndroidManifest.xml": moduleManifest(t, "com.example.middle"),
+	})
+
+	for i := 0; i < 5; i++ {
+		modules, err := aab.Modules()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, m := range modules {
+			names = append(names, m.Name)
+		}
+		assert.Equal(t, []string{"alpha", "middle", "zebra"}, names)
+	}
+}
+
+func TestModulesSkipsDirectoriesWithoutManifest(t *testing.T) {
+	aab := newZipAab(t, map[string][]byte{
+		"META-INF/CERT.RSA": []byte("not a module"),
+	})
+
+	modules, err := aab.Modules()
+	assert.NoError(t, err)
+	assert.Empty(t, modules)
+}
+
+func TestModulesPropagatesCorruptManifestError(t *testing.T) {
+	aab := newZipAab(t, map[string][]byte{
+		"broken/manifest/AndroidManifest.xml": []byte("this is not a valid protobuf message"),
+	})
+
+	modules, err := aab.Modules()
+	assert.Error(t, err)
+	assert.Nil(t, modules)
+}
+
+func TestParseDeliveryDefaultsToInstallTime(t *testing.T) {
+	manifest := &pb.XmlElement{Name: "manifest"}
+	assert.Equal(t, DeliveryInstallTime, parseDelivery(manifest))
+}
+
+func TestParseDeliveryOnDemand(t *testing.T) {
+	manifest := &pb.XmlElement{
+		Name: "manifest",
+		Child: []*pb.XmlNode{
+			elementNode(&pb.XmlElement{
+				Name: "module",
+				Child: []*pb.XmlNode{
+					elementNode(&pb.XmlElement{Name: "on-demand"}),
+				},
+			}),
+		},
+	}
+	assert.Equal(t, DeliveryOnDemand, parseDelivery(manifest))
+}
@@ -0,0 +1,254 @@
+// Package pb holds the protobuf message types used to decode an AAB's
+// embedded metadata. BundleConfig mirrors the subset of bundletool's
+// BundleConfig.proto (com.android.tools.build.bundletool.BundleConfig)
+// that callers of this package need, intentionally decoded with protowire
+// directly rather than through generated reflection code.
+//
+// bundle_config.proto, checked in alongside this file, is the schema source
+// for the fields decoded here, copied from bundletool's own
+// BundleConfig.proto — it's what a protoc-gen-go pass would regenerate this
+// file from once protoc is available in the build environment; this file is
+// a hand-written stand-in until then, not a substitute for running codegen.
+//
+// This is a hand-written partial decoder, not a generated one: it only
+// understands bundletool_version (Bundletool.version), the split dimensions
+// under optimizations.splits_config, and compression.uncompressed_glob.
+// Every other BundleConfig field — asset_modules_config, master_resources,
+// type, stamp, app_bundle, and any field bundletool adds in the future — is
+// consciously out of scope and is skipped as an unknown field rather than
+// surfaced, so callers must not assume BundleConfig reflects the full
+// message.
+package pb
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// SplitDimension identifies a dimension bundletool may split the APK set on.
+type SplitDimension int32
+
+const (
+	SplitDimensionUnspecified SplitDimension = iota
+	SplitDimensionABI
+	SplitDimensionScreenDensity
+	SplitDimensionLanguage
+	SplitDimensionTextureCompressionFormat
+	SplitDimensionDeviceTier
+)
+
+// Bundletool holds the version of bundletool that built the AAB.
+type Bundletool struct {
+	Version string
+}
+
+// Compression holds the compression settings bundletool applied when
+// building the AAB.
+type Compression struct {
+	UncompressedGlob []string
+}
+
+// Optimizations holds the split dimensions bundletool was configured to
+// generate split APKs for.
+type Optimizations struct {
+	SplitDimension []SplitDimension
+}
+
+// BundleConfig is the decoded BUNDLE-METADATA/com.android.tools.build.bundletool/BundleConfig.pb
+// file embedded in every AAB. Only the fields below are decoded; see the
+// package doc comment for which BundleConfig fields are out of scope.
+type BundleConfig struct {
+	Bundletool    *Bundletool
+	Optimizations *Optimizations
+	Compression   *Compression
+}
+
+// UnmarshalBundleConfig decodes a BundleConfig.pb message. Unknown fields are
+// skipped; only the fields BundleConfig exposes are parsed.
+func UnmarshalBundleConfig(data []byte) (*BundleConfig, error) {
+	cfg := &BundleConfig{}
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		msg, n, err := consumeField(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+
+		switch num {
+		case 1: // bundletool
+			bt, err := unmarshalBundletool(msg)
+			if err != nil {
+				return nil, fmt.Errorf("bundletool: %w", err)
+			}
+			cfg.Bundletool = bt
+		case 2: // optimizations
+			opt, err := unmarshalOptimizations(msg)
+			if err != nil {
+				return nil, fmt.Errorf("optimizations: %w", err)
+			}
+			cfg.Optimizations = opt
+		case 3: // compression
+			c, err := unmarshalCompression(msg)
+			if err != nil {
+				return nil, fmt.Errorf("compression: %w", err)
+			}
+			cfg.Compression = c
+		}
+	}
+	return cfg, nil
+}
+
+// consumeField returns the raw bytes for a length-delimited field (the only
+// wire type BundleConfig's message fields use) along with how many bytes
+// were consumed from data, which starts right after the field's tag.
+func consumeField(typ protowire.Type, data []byte) (msg []byte, n int, err error) {
+	switch typ {
+	case protowire.BytesType:
+		v, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return nil, 0, protowire.ParseError(n)
+		}
+		return v, n, nil
+	case protowire.VarintType:
+		_, n := protowire.ConsumeVarint(data)
+		if n < 0 {
+			return nil, 0, protowire.ParseError(n)
+		}
+		return nil, n, nil
+	case protowire.Fixed32Type:
+		_, n := protowire.ConsumeFixed32(data)
+		if n < 0 {
+			return nil, 0, protowire.ParseError(n)
+		}
+		return nil, n, nil
+	case protowire.Fixed64Type:
+		_, n := protowire.ConsumeFixed64(data)
+		if n < 0 {
+			return nil, 0, protowire.ParseError(n)
+		}
+		return nil, n, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported wire type %v", typ)
+	}
+}
+
+func unmarshalBundletool(data []byte) (*Bundletool, error) {
+	bt := &Bundletool{}
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		msg, n, err := consumeField(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+		if num == 1 { // version
+			bt.Version = string(msg)
+		}
+	}
+	return bt, nil
+}
+
+func unmarshalOptimizations(data []byte) (*Optimizations, error) {
+	opt := &Optimizations{}
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		msg, n, err := consumeField(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+		if num == 1 { // splits_config
+			dims, err := unmarshalSplitsConfig(msg)
+			if err != nil {
+				return nil, err
+			}
+			opt.SplitDimension = dims
+		}
+	}
+	return opt, nil
+}
+
+func unmarshalSplitsConfig(data []byte) ([]SplitDimension, error) {
+	var dims []SplitDimension
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		msg, n, err := consumeField(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+		if num == 1 { // split_dimension
+			dim, err := unmarshalSplitDimension(msg)
+			if err != nil {
+				return nil, err
+			}
+			dims = append(dims, dim)
+		}
+	}
+	return dims, nil
+}
+
+func unmarshalSplitDimension(data []byte) (SplitDimension, error) {
+	var value SplitDimension
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return 0, protowire.ParseError(n)
+		}
+		data = data[n:]
+		if typ == protowire.VarintType && num == 1 { // value
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return 0, protowire.ParseError(n)
+			}
+			value = SplitDimension(v)
+			data = data[n:]
+			continue
+		}
+		_, n, err := consumeField(typ, data)
+		if err != nil {
+			return 0, err
+		}
+		data = data[n:]
+	}
+	return value, nil
+}
+
+func unmarshalCompression(data []byte) (*Compression, error) {
+	c := &Compression{}
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		data = data[n:]
+		msg, n, err := consumeField(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+		if num == 1 && typ == protowire.BytesType { // uncompressed_glob
+			c.UncompressedGlob = append(c.UncompressedGlob, string(msg))
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,46 @@
+package pb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendMessage(buf []byte, num protowire.Number, msg []byte) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, msg)
+}
+
+func TestUnmarshalBundleConfig(t *testing.T) {
+	splitDimension := protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), uint64(SplitDimensionABI))
+	splitsConfig := appendMessage(nil, 1, splitDimension)
+	optimizations := appendMessage(nil, 1, splitsConfig)
+	bundletool := appendMessage(nil, 1, []byte("1.15.0"))
+	compression := appendMessage(nil, 1, []byte("**.so"))
+
+	var data []byte
+	data = appendMessage(data, 1, bundletool)
+	data = appendMessage(data, 2, optimizations)
+	data = appendMessage(data, 3, compression)
+	// An unrecognized top-level field should be skipped, not fail the parse.
+	data = protowire.AppendVarint(protowire.AppendTag(data, 99, protowire.VarintType), 42)
+
+	cfg, err := UnmarshalBundleConfig(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "1.15.0", cfg.Bundletool.Version)
+	assert.Equal(t, []SplitDimension{SplitDimensionABI}, cfg.Optimizations.SplitDimension)
+	assert.Equal(t, []string{"**.so"}, cfg.Compression.UncompressedGlob)
+}
+
+func TestUnmarshalBundleConfigEmpty(t *testing.T) {
+	cfg, err := UnmarshalBundleConfig(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, cfg.Bundletool)
+	assert.Nil(t, cfg.Optimizations)
+	assert.Nil(t, cfg.Compression)
+}
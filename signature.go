@@ -0,0 +1,89 @@
+package aab
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// Certificate is the information apksigner/jarsigner print for a signer's
+// certificate: its fingerprints, distinguished names, and validity window.
+type Certificate struct {
+	Subject   pkix.Name
+	Issuer    pkix.Name
+	NotBefore time.Time
+	NotAfter  time.Time
+	SHA1      [20]byte
+	SHA256    [32]byte
+
+	raw *x509.Certificate
+}
+
+// Raw returns the parsed x509 certificate backing this Certificate.
+func (c *Certificate) Raw() *x509.Certificate {
+	return c.raw
+}
+
+// Signature holds the signer certificates recovered from an AAB's
+// META-INF/*.{RSA,DSA,EC} PKCS#7 signature block, analogous to what
+// `apksigner verify --print-certs` reports.
+type Signature struct {
+	Certificates []*Certificate
+}
+
+// Signature parses the jar signing block(s) under META-INF/ and returns the
+// signer certificates. It returns an error if the AAB has no recognizable
+// signature block.
+func (a *Aab) Signature() (*Signature, error) {
+	var blocks []string
+	for name := range a.files {
+		if !strings.HasPrefix(name, "META-INF/") {
+			continue
+		}
+		upper := strings.ToUpper(name)
+		if strings.HasSuffix(upper, ".RSA") || strings.HasSuffix(upper, ".DSA") || strings.HasSuffix(upper, ".EC") {
+			blocks = append(blocks, name)
+		}
+	}
+	sort.Strings(blocks)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no signature block found under META-INF/")
+	}
+
+	sig := &Signature{}
+	for _, name := range blocks {
+		rc, err := a.OpenEntry(name)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		p7, err := pkcs7.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		for _, cert := range p7.Certificates {
+			sig.Certificates = append(sig.Certificates, &Certificate{
+				Subject:   cert.Subject,
+				Issuer:    cert.Issuer,
+				NotBefore: cert.NotBefore,
+				NotAfter:  cert.NotAfter,
+				SHA1:      sha1.Sum(cert.Raw),
+				SHA256:    sha256.Sum256(cert.Raw),
+				raw:       cert,
+			})
+		}
+	}
+	return sig, nil
+}
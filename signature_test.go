@@ -0,0 +1,99 @@
+package aab
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mozilla.org/pkcs7"
+)
+
+// signTestApk builds a self-signed jarsigner-style PKCS#7 block the way
+// bundletool embeds one under META-INF/ for a signed AAB, returning the
+// signing certificate alongside the encoded block.
+func signTestApk(t *testing.T) (*x509.Certificate, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-signer"},
+		NotBefore:    time.Unix(0, 0),
+		NotAfter:     time.Unix(0, 0).AddDate(30, 0, 0),
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sd, err := pkcs7.NewSignedData([]byte("signed content"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sd.AddSigner(cert, key, pkcs7.SignerInfoConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	block, err := sd.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, block
+}
+
+func TestSignatureReturnsSignerCertificate(t *testing.T) {
+	cert, block := signTestApk(t)
+	aab := newZipAab(t, map[string][]byte{
+		"META-INF/CERT.RSA": block,
+	})
+
+	sig, err := aab.Signature()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, sig.Certificates, 1) {
+		got := sig.Certificates[0]
+		assert.Equal(t, "test-signer", got.Subject.CommonName)
+		assert.Equal(t, sha256.Sum256(cert.Raw), got.SHA256)
+	}
+}
+
+func TestSignatureOrdersMultipleBlocksDeterministically(t *testing.T) {
+	certA, blockA := signTestApk(t)
+	certB, blockB := signTestApk(t)
+	aab := newZipAab(t, map[string][]byte{
+		"META-INF/Z.RSA": blockB,
+		"META-INF/A.RSA": blockA,
+	})
+
+	for i := 0; i < 5; i++ {
+		sig, err := aab.Signature()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assert.Len(t, sig.Certificates, 2) {
+			assert.Equal(t, sha256.Sum256(certA.Raw), sig.Certificates[0].SHA256)
+			assert.Equal(t, sha256.Sum256(certB.Raw), sig.Certificates[1].SHA256)
+		}
+	}
+}
+
+func TestSignatureNoBlockFound(t *testing.T) {
+	aab := newZipAab(t, map[string][]byte{
+		"base/resources.pb": []byte("not a signature"),
+	})
+
+	_, err := aab.Signature()
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package aab
+
+import (
+	"strings"
+
+	"github.com/xmxu/aab-parser/pb"
+)
+
+// localeTag returns the BCP-47 tag a ConfigValue's Configuration represents,
+// using "default" for the locale-less fallback entry the way aapt2 resource
+// tables do.
+func localeTag(config *pb.Configuration) string {
+	if config == nil || config.Locale == "" {
+		return "default"
+	}
+	return config.Locale
+}
+
+// stringEntriesByLocale collects every ConfigValue for the t/name resource
+// entry, keyed by the BCP-47 tag of the config it was compiled for.
+func stringEntriesByLocale(resource *pb.Package, t, name string) map[string]string {
+	values := map[string]string{}
+	if resource == nil {
+		return values
+	}
+	for _, tt := range resource.Type {
+		if tt.Name != t {
+			continue
+		}
+		for _, e := range tt.Entry {
+			if e.Name != name {
+				continue
+			}
+			for _, c := range e.ConfigValue {
+				item := c.Value.GetItem()
+				if item == nil {
+					continue
+				}
+				str := item.GetStr()
+				if str == nil {
+					continue
+				}
+				values[localeTag(c.Config)] = str.Value
+			}
+		}
+	}
+	return values
+}
+
+// Labels returns the app's label in every locale the AAB ships a translation
+// for, keyed by BCP-47 tag.
+func (a *Aab) Labels() map[string]string {
+	parts, ok := splitResourceRef(a.manifest.App.Label)
+	if !ok {
+		return map[string]string{}
+	}
+	return stringEntriesByLocale(a.resource, parts[0], parts[1])
+}
+
+// String looks up an arbitrary string resource (app description, permission
+// rationale, notification channel name, ...) by name for the given config.
+func (a *Aab) String(name string, config *pb.Configuration) (string, bool) {
+	value, err := resolveResource(a.resource, "string", name, config)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+// StringAll returns every locale translation of the named string resource,
+// keyed by BCP-47 tag.
+func (a *Aab) StringAll(name string) map[string]string {
+	return stringEntriesByLocale(a.resource, "string", name)
+}
+
+// splitResourceRef splits a "type/name" resource reference such as the
+// manifest's icon/label attributes use, e.g. "string/app_name".
+func splitResourceRef(ref string) (parts [2]string, ok bool) {
+	if len(ref) == 0 {
+		return parts, false
+	}
+	p := strings.Split(ref, "/")
+	if len(p) != 2 {
+		return parts, false
+	}
+	return [2]string{p[0], p[1]}, true
+}
@@ -0,0 +1,85 @@
+package aab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xmxu/aab-parser/pb"
+)
+
+// stringEntry builds a string-typed resource value the way a compiled
+// resources.pb stores a <string name="...">...</string> translation.
+func stringEntry(config *pb.Configuration, value string) *pb.ConfigValue {
+	return &pb.ConfigValue{
+		Config: config,
+		Value: &pb.Value{
+			Value: &pb.Value_Item{
+				Item: &pb.Item{
+					Value: &pb.Item_Str{Str: &pb.String{Value: value}},
+				},
+			},
+		},
+	}
+}
+
+func newResourceAab(t *testing.T, entryName string, values ...*pb.ConfigValue) *Aab {
+	t.Helper()
+	aab := newZipAab(t, nil)
+	aab.resource = &pb.Package{
+		Type: []*pb.Type{
+			{
+				Name: "string",
+				Entry: []*pb.Entry{
+					{Name: entryName, ConfigValue: values},
+				},
+			},
+		},
+	}
+	return aab
+}
+
+func TestStringAllReturnsEveryLocale(t *testing.T) {
+	aab := newResourceAab(t, "app_name",
+		stringEntry(&pb.Configuration{}, "My App"),
+		stringEntry(&pb.Configuration{Locale: "fr"}, "Mon App"),
+		stringEntry(&pb.Configuration{Locale: "de"}, "Meine App"),
+	)
+
+	assert.Equal(t, map[string]string{
+		"default": "My App",
+		"fr":      "Mon App",
+		"de":      "Meine App",
+	}, aab.StringAll("app_name"))
+}
+
+func TestStringLooksUpByNameAndConfig(t *testing.T) {
+	aab := newResourceAab(t, "app_name",
+		stringEntry(&pb.Configuration{}, "My App"),
+		stringEntry(&pb.Configuration{Locale: "fr"}, "Mon App"),
+	)
+
+	value, ok := aab.String("app_name", &pb.Configuration{Locale: "fr"})
+	assert.True(t, ok)
+	assert.Equal(t, "Mon App", value)
+
+	_, ok = aab.String("does_not_exist", nil)
+	assert.False(t, ok)
+}
+
+func TestLabelsUsesManifestLabelReference(t *testing.T) {
+	aab := newResourceAab(t, "app_name",
+		stringEntry(&pb.Configuration{}, "My App"),
+		stringEntry(&pb.Configuration{Locale: "ja"}, "マイアプリ"),
+	)
+	aab.manifest.App.Label = "string/app_name"
+
+	assert.Equal(t, map[string]string{
+		"default": "My App",
+		"ja":      "マイアプリ",
+	}, aab.Labels())
+}
+
+func TestLabelsWithoutLabelReference(t *testing.T) {
+	aab := newResourceAab(t, "app_name", stringEntry(&pb.Configuration{}, "My App"))
+	assert.Empty(t, aab.Labels())
+}
@@ -0,0 +1,43 @@
+package aab
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+)
+
+// newZipAab builds an in-memory zip from the given name->content entries and
+// wraps it in an *Aab, without going through OpenZipReader's manifest and
+// resource-table parsing. It's used by tests that only need OpenEntry/the
+// file index, or that want to set a.resource/a.manifest directly.
+func newZipAab(t *testing.T, files map[string][]byte) *Aab {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatalf("creating %s: %v", name, err)
+		}
+		if _, err := f.Write(content); err != nil {
+			t.Fatalf("writing %s: %v", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("closing zip writer: %v", err)
+	}
+	r := bytes.NewReader(buf.Bytes())
+	zipreader, err := zip.NewReader(r, int64(r.Len()))
+	if err != nil {
+		t.Fatalf("opening zip reader: %v", err)
+	}
+	index := make(map[string]*zip.File, len(zipreader.File))
+	for _, f := range zipreader.File {
+		index[f.Name] = f
+	}
+	return &Aab{
+		zipreader: zipreader,
+		files:     index,
+		manifest:  &Manifest{App: Application{}},
+	}
+}